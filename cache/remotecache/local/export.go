@@ -0,0 +1,28 @@
+package local
+
+import (
+	"github.com/moby/buildkit/cache/cacheimport"
+	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/session"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// ResolveCacheExporterFunc returns a cache exporter that writes an OCI
+// image-layout cache manifest to the requesting client's filesystem over
+// its session. It is registered under the "local" cache type.
+func ResolveCacheExporterFunc(sm *session.Manager) remotecache.ResolveCacheExporterFunc {
+	return func(ctx context.Context, attrs map[string]string) (remotecache.Exporter, error) {
+		dest, ok := attrs["dest"]
+		if !ok {
+			return nil, errors.New("local cache exporter requires dest attribute")
+		}
+		caller, err := callerFromContext(ctx, sm)
+		if err != nil {
+			return nil, err
+		}
+		return cacheimport.NewCacheExporter(cacheimport.ExporterOpt{
+			Remote: &remote{caller: caller, dir: dest},
+		}), nil
+	}
+}
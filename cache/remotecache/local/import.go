@@ -0,0 +1,37 @@
+package local
+
+import (
+	"github.com/moby/buildkit/cache/cacheimport"
+	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/session"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// ResolveCacheImporterFunc returns a cache importer that reads a
+// previously exported OCI image-layout cache manifest back from the
+// requesting client's filesystem over its session. Registered under the
+// "local" cache type.
+func ResolveCacheImporterFunc(sm *session.Manager) remotecache.ResolveCacheImporterFunc {
+	return func(ctx context.Context, attrs map[string]string) (remotecache.Importer, error) {
+		src, ok := attrs["src"]
+		if !ok {
+			return nil, errors.New("local cache importer requires src attribute")
+		}
+		caller, err := callerFromContext(ctx, sm)
+		if err != nil {
+			return nil, err
+		}
+		return cacheimport.NewCacheImporter(cacheimport.ImporterOpt{
+			Remote: &remote{caller: caller, dir: src},
+		}), nil
+	}
+}
+
+func callerFromContext(ctx context.Context, sm *session.Manager) (session.Caller, error) {
+	sessionID := session.FromContext(ctx)
+	if sessionID == "" {
+		return nil, errors.New("local cache requires an attached session")
+	}
+	return sm.Get(ctx, sessionID)
+}
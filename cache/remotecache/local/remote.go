@@ -0,0 +1,38 @@
+package local
+
+import (
+	"io"
+	"path"
+
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/filesync"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// remote implements cacheimport.Remote by laying the cache manifest and its
+// blobs out as an OCI image layout (https://github.com/opencontainers/image-spec/blob/main/image-layout.md)
+// synced to/from the client's filesystem over its session, rather than a
+// registry.
+type remote struct {
+	caller session.Caller
+	dir    string
+}
+
+func (r *remote) Push(ctx context.Context, desc ocispec.Descriptor, rc io.Reader) error {
+	return filesync.SendFile(ctx, r.caller, blobPath(r.dir, desc.Digest), rc)
+}
+
+func (r *remote) Pull(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := filesync.FetchFile(ctx, r.caller, blobPath(r.dir, desc.Digest))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch cache blob %s", desc.Digest)
+	}
+	return rc, nil
+}
+
+func blobPath(dir string, dgst digest.Digest) string {
+	return path.Join(dir, "blobs", dgst.Algorithm().String(), dgst.Encoded())
+}
@@ -0,0 +1,25 @@
+package registry
+
+import (
+	"github.com/moby/buildkit/cache/cacheimport"
+	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/util/resolver"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// ResolveCacheExporterFunc returns a cache exporter that pushes the cache
+// manifest and its blobs to an OCI registry. It is registered under the
+// "registry" cache type.
+func ResolveCacheExporterFunc() remotecache.ResolveCacheExporterFunc {
+	return func(ctx context.Context, attrs map[string]string) (remotecache.Exporter, error) {
+		ref, ok := attrs["ref"]
+		if !ok {
+			return nil, errors.New("registry cache exporter requires ref attribute")
+		}
+		optFunc, _ := resolver.FromContext(ctx)
+		return cacheimport.NewCacheExporter(cacheimport.ExporterOpt{
+			Remote: newRemote(ctx, ref, optFunc),
+		}), nil
+	}
+}
@@ -0,0 +1,25 @@
+package registry
+
+import (
+	"github.com/moby/buildkit/cache/cacheimport"
+	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/util/resolver"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// ResolveCacheImporterFunc returns a cache importer that reads a
+// previously exported cache manifest back from an OCI registry. It is
+// registered under the "registry" cache type.
+func ResolveCacheImporterFunc() remotecache.ResolveCacheImporterFunc {
+	return func(ctx context.Context, attrs map[string]string) (remotecache.Importer, error) {
+		ref, ok := attrs["ref"]
+		if !ok {
+			return nil, errors.New("registry cache importer requires ref attribute")
+		}
+		optFunc, _ := resolver.FromContext(ctx)
+		return cacheimport.NewCacheImporter(cacheimport.ImporterOpt{
+			Remote: newRemote(ctx, ref, optFunc),
+		}), nil
+	}
+}
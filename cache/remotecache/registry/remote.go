@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"io"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// remote implements cacheimport.Remote by pushing/pulling the cache
+// manifest and its blobs to/from an OCI registry, using a resolver built
+// with whatever docker.ResolverOptions the request's ResolverOptFunc (if
+// any) returned for ref.
+type remote struct {
+	ref      string
+	resolver remotes.Resolver
+}
+
+func (r *remote) Push(ctx context.Context, desc ocispec.Descriptor, rc io.Reader) error {
+	pusher, err := r.resolver.Pusher(ctx, r.ref)
+	if err != nil {
+		return err
+	}
+	cw, err := pusher.Push(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer cw.Close()
+	if _, err := io.Copy(cw, rc); err != nil {
+		return errors.Wrapf(err, "failed to push cache blob %s", desc.Digest)
+	}
+	return cw.Commit(ctx, desc.Size, desc.Digest)
+}
+
+func (r *remote) Pull(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	fetcher, err := r.resolver.Fetcher(ctx, r.ref)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch cache blob %s", desc.Digest)
+	}
+	return rc, nil
+}
+
+func newRemote(ctx context.Context, ref string, optFunc func(string) docker.ResolverOptions) *remote {
+	var opts docker.ResolverOptions
+	if optFunc != nil {
+		opts = optFunc(ref)
+	}
+	return &remote{ref: ref, resolver: docker.NewResolver(opts)}
+}
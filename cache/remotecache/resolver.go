@@ -0,0 +1,30 @@
+package remotecache
+
+import (
+	"golang.org/x/net/context"
+)
+
+// Exporter writes out the cache chains produced by a solve so that a later
+// build can import them. Each backend (registry, local, inline, ...) provides
+// its own Exporter that knows how to serialize and publish cache blobs.
+type Exporter interface {
+	// Finalize flushes any buffered cache metadata/blobs to the backend and
+	// returns exporter-specific metadata (e.g. a resulting digest).
+	// SolveResponse carries no such metadata back to the client.
+	Finalize(ctx context.Context) (map[string]string, error)
+}
+
+// Importer loads a previously exported cache chain so the solver can consult
+// it while matching cache keys.
+type Importer interface {
+	Load(ctx context.Context) error
+}
+
+// ResolveCacheExporterFunc builds a cache Exporter for a given set of
+// attributes. Implementations are registered on control.Opt keyed by a
+// type name such as "registry" or "local".
+type ResolveCacheExporterFunc func(ctx context.Context, attrs map[string]string) (Exporter, error)
+
+// ResolveCacheImporterFunc builds a cache Importer for a given set of
+// attributes. See ResolveCacheExporterFunc.
+type ResolveCacheImporterFunc func(ctx context.Context, attrs map[string]string) (Importer, error)
@@ -1,11 +1,12 @@
 package control
 
 import (
+	"sync"
+
 	"github.com/containerd/containerd/snapshot"
-	"github.com/docker/distribution/reference"
 	controlapi "github.com/moby/buildkit/api/services/control"
 	"github.com/moby/buildkit/cache"
-	"github.com/moby/buildkit/cache/cacheimport"
+	"github.com/moby/buildkit/cache/remotecache"
 	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/exporter"
 	"github.com/moby/buildkit/frontend"
@@ -13,7 +14,10 @@ import (
 	"github.com/moby/buildkit/session/grpchijack"
 	"github.com/moby/buildkit/solver"
 	"github.com/moby/buildkit/source"
+	"github.com/moby/buildkit/util/dns"
+	"github.com/moby/buildkit/util/resolver"
 	"github.com/moby/buildkit/worker"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -22,36 +26,49 @@ import (
 )
 
 type Opt struct {
-	Snapshotter      snapshot.Snapshotter
-	CacheManager     cache.Manager
-	Worker           worker.Worker
-	SourceManager    *source.Manager
-	InstructionCache solver.InstructionCache
-	Exporters        map[string]exporter.Exporter
-	SessionManager   *session.Manager
-	Frontends        map[string]frontend.Frontend
-	ImageSource      source.Source
-	CacheExporter    *cacheimport.CacheExporter
-	CacheImporter    *cacheimport.CacheImporter
+	Snapshotter               snapshot.Snapshotter
+	CacheManager              cache.Manager
+	WorkerController          *worker.Controller
+	SourceManager             *source.Manager
+	InstructionCache          solver.InstructionCache
+	Exporters                 map[string]exporter.Exporter
+	SessionManager            *session.Manager
+	Frontends                 map[string]frontend.Frontend
+	ImageSource               source.Source
+	ResolveCacheExporterFuncs map[string]remotecache.ResolveCacheExporterFunc
+	ResolveCacheImporterFuncs map[string]remotecache.ResolveCacheImporterFunc
+	DNSConfig                 *dns.Config
+	ResolverOptFunc           resolver.ResolverOptFunc
 }
 
 type Controller struct { // TODO: ControlService
 	opt    Opt
 	solver *solver.Solver
+
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+// job ties together the lifetime of a single Solve call with any Status
+// streams watching the same ref, so that Cancel can stop both.
+type job struct {
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 func NewController(opt Opt) (*Controller, error) {
 	c := &Controller{
-		opt: opt,
+		opt:  opt,
+		jobs: map[string]*job{},
 		solver: solver.NewLLBSolver(solver.LLBOpt{
 			SourceManager:    opt.SourceManager,
 			CacheManager:     opt.CacheManager,
-			Worker:           opt.Worker,
+			WorkerController: opt.WorkerController,
 			InstructionCache: opt.InstructionCache,
 			ImageSource:      opt.ImageSource,
 			Frontends:        opt.Frontends,
-			CacheExporter:    opt.CacheExporter,
-			CacheImporter:    opt.CacheImporter,
+			DNS:              opt.DNSConfig,
+			ResolverOptFunc:  opt.ResolverOptFunc,
 		}),
 	}
 	return c, nil
@@ -87,7 +104,61 @@ func (c *Controller) DiskUsage(ctx context.Context, r *controlapi.DiskUsageReque
 	return resp, nil
 }
 
+func (c *Controller) ListWorkers(ctx context.Context, r *controlapi.ListWorkersRequest) (*controlapi.ListWorkersResponse, error) {
+	workers, err := c.opt.WorkerController.List(r.Filter...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &controlapi.ListWorkersResponse{}
+	for _, w := range workers {
+		resp.Record = append(resp.Record, &controlapi.WorkerRecord{
+			ID:        w.ID(),
+			Labels:    w.Labels(),
+			Platforms: toPBPlatforms(w.Platforms()),
+		})
+	}
+	return resp, nil
+}
+
+func (c *Controller) Info(ctx context.Context, r *controlapi.InfoRequest) (*controlapi.InfoResponse, error) {
+	workers, err := c.opt.WorkerController.List()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &controlapi.InfoResponse{}
+	for _, w := range workers {
+		resp.Worker = append(resp.Worker, &controlapi.WorkerRecord{
+			ID:        w.ID(),
+			Labels:    w.Labels(),
+			Platforms: toPBPlatforms(w.Platforms()),
+		})
+	}
+	return resp, nil
+}
+
+func (c *Controller) Cancel(ctx context.Context, req *controlapi.CancelRequest) (*controlapi.CancelResponse, error) {
+	c.mu.Lock()
+	j, ok := c.jobs[req.Ref]
+	c.mu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("no such job: %s", req.Ref)
+	}
+	j.cancel()
+	return &controlapi.CancelResponse{}, nil
+}
+
 func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*controlapi.SolveResponse, error) {
+	ctx, err := c.registerJob(ctx, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	defer c.deregisterJob(req.Ref)
+	if c.opt.ResolverOptFunc != nil {
+		ctx = resolver.WithResolverOptFunc(ctx, c.opt.ResolverOptFunc)
+	}
+
 	var frontend frontend.Frontend
 	if req.Frontend != "" {
 		var ok bool
@@ -100,7 +171,6 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 	ctx = session.NewContext(ctx, req.Session)
 
 	var expi exporter.ExporterInstance
-	var err error
 	if req.Exporter != "" {
 		exp, ok := c.opt.Exporters[req.Exporter]
 		if !ok {
@@ -112,22 +182,47 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		}
 	}
 
-	exportCacheRef := ""
-	if ref := req.Cache.ExportRef; ref != "" {
-		parsed, err := reference.ParseNormalizedNamed(ref)
+	var cacheExporter remotecache.Exporter
+	if exp := req.Cache.Exports; len(exp) > 0 {
+		if len(exp) > 1 {
+			return nil, errors.Errorf("only a single cache export is currently supported, got %d", len(exp))
+		}
+		entry := exp[0]
+		resolveCacheExporter, ok := c.opt.ResolveCacheExporterFuncs[entry.Type]
+		if !ok {
+			return nil, errors.Errorf("unknown cache exporter: %q", entry.Type)
+		}
+		cacheExporter, err = resolveCacheExporter(ctx, entry.Attrs)
 		if err != nil {
 			return nil, err
 		}
-		exportCacheRef = reference.TagNameOnly(parsed).String()
 	}
 
-	importCacheRef := ""
-	if ref := req.Cache.ImportRef; ref != "" {
-		parsed, err := reference.ParseNormalizedNamed(ref)
+	var cacheImporters []remotecache.Importer
+	for _, entry := range req.Cache.Imports {
+		resolveCacheImporter, ok := c.opt.ResolveCacheImporterFuncs[entry.Type]
+		if !ok {
+			return nil, errors.Errorf("unknown cache importer: %q", entry.Type)
+		}
+		cacheImporter, err := resolveCacheImporter(ctx, entry.Attrs)
 		if err != nil {
 			return nil, err
 		}
-		importCacheRef = reference.TagNameOnly(parsed).String()
+		cacheImporters = append(cacheImporters, cacheImporter)
+	}
+
+	dnsConfig := c.opt.DNSConfig
+	if req.DNS != nil {
+		dnsConfig = &dns.Config{
+			Nameservers:   req.DNS.Nameservers,
+			SearchDomains: req.DNS.SearchDomains,
+			Options:       req.DNS.Options,
+		}
+	}
+
+	workers, err := c.resolveWorkers(req.Platforms)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := c.solver.Solve(ctx, req.Ref, solver.SolveRequest{
@@ -135,18 +230,61 @@ func (c *Controller) Solve(ctx context.Context, req *controlapi.SolveRequest) (*
 		Definition:     req.Definition,
 		Exporter:       expi,
 		FrontendOpt:    req.FrontendAttrs,
-		ExportCacheRef: exportCacheRef,
-		ImportCacheRef: importCacheRef,
+		CacheExporter:  cacheExporter,
+		CacheImporters: cacheImporters,
+		DNS:            dnsConfig,
+		Workers:        workers,
 	}); err != nil {
 		return nil, err
 	}
 	return &controlapi.SolveResponse{}, nil
 }
 
+// resolveWorkers picks the worker to build each requested platform on,
+// falling back to the controller's default worker when no platforms were
+// requested.
+func (c *Controller) resolveWorkers(platforms []*controlapi.Platform) ([]worker.Worker, error) {
+	if len(platforms) == 0 {
+		w, err := c.opt.WorkerController.GetDefault()
+		if err != nil {
+			return nil, err
+		}
+		return []worker.Worker{w}, nil
+	}
+
+	workers := make([]worker.Worker, 0, len(platforms))
+	for _, p := range platforms {
+		w, err := c.opt.WorkerController.Get(ocispecs.Platform{
+			OS:           p.OS,
+			Architecture: p.Architecture,
+			Variant:      p.Variant,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "no worker for platform %s/%s", p.OS, p.Architecture)
+		}
+		workers = append(workers, w)
+	}
+	return workers, nil
+}
+
 func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Control_StatusServer) error {
 	ch := make(chan *client.SolveStatus, 8)
 
-	eg, ctx := errgroup.WithContext(stream.Context())
+	statusCtx := stream.Context()
+	if jobCtx, ok := c.jobContext(req.Ref); ok {
+		var cancel context.CancelFunc
+		statusCtx, cancel = context.WithCancel(statusCtx)
+		defer cancel()
+		go func() {
+			select {
+			case <-jobCtx.Done():
+				cancel()
+			case <-statusCtx.Done():
+			}
+		}()
+	}
+
+	eg, ctx := errgroup.WithContext(statusCtx)
 	eg.Go(func() error {
 		return c.solver.Status(ctx, req.Ref, ch)
 	})
@@ -198,6 +336,54 @@ func (c *Controller) Status(req *controlapi.StatusRequest, stream controlapi.Con
 	return eg.Wait()
 }
 
+func toPBPlatforms(in []ocispecs.Platform) []*controlapi.Platform {
+	out := make([]*controlapi.Platform, 0, len(in))
+	for _, p := range in {
+		out = append(out, &controlapi.Platform{
+			OS:           p.OS,
+			Architecture: p.Architecture,
+			Variant:      p.Variant,
+		})
+	}
+	return out
+}
+
+func (c *Controller) registerJob(parent context.Context, ref string) (context.Context, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.jobs[ref]; ok {
+		return nil, errors.Errorf("job ref %s already in use", ref)
+	}
+	ctx, cancel := context.WithCancel(parent)
+	c.jobs[ref] = &job{ctx: ctx, cancel: cancel}
+	return ctx, nil
+}
+
+func (c *Controller) deregisterJob(ref string) {
+	c.mu.Lock()
+	// registerJob refuses a ref that is already in flight, so by the time
+	// we get here the entry (if any) can only be the one we registered.
+	j, ok := c.jobs[ref]
+	delete(c.jobs, ref)
+	c.mu.Unlock()
+	if ok {
+		j.cancel()
+	}
+}
+
+// jobContext returns the context tracking the in-flight Solve registered
+// under ref, if any, so a Status stream for the same ref is cancelled
+// together with it.
+func (c *Controller) jobContext(ref string) (context.Context, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	j, ok := c.jobs[ref]
+	if !ok {
+		return nil, false
+	}
+	return j.ctx, true
+}
+
 func (c *Controller) Session(stream controlapi.Control_SessionServer) error {
 	logrus.Debugf("session started")
 	conn, opts := grpchijack.Hijack(stream)
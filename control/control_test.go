@@ -0,0 +1,99 @@
+package control
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/solver"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeStatusServer implements controlapi.Control_StatusServer backed by a
+// caller-supplied context, so tests can drive Controller.Status directly
+// and observe when that context is cancelled.
+type fakeStatusServer struct {
+	ctx context.Context
+}
+
+func (f *fakeStatusServer) Send(*controlapi.StatusResponse) error    { return nil }
+func (f *fakeStatusServer) SendMsg(m interface{}) error              { return nil }
+func (f *fakeStatusServer) RecvMsg(m interface{}) error              { return nil }
+func (f *fakeStatusServer) SetHeader(metadata.MD) error              { return nil }
+func (f *fakeStatusServer) SendHeader(metadata.MD) error             { return nil }
+func (f *fakeStatusServer) SetTrailer(metadata.MD)                   {}
+func (f *fakeStatusServer) Context() context.Context                { return f.ctx }
+
+func TestRegisterJobRefAlreadyInUse(t *testing.T) {
+	c := &Controller{jobs: map[string]*job{}}
+
+	_, err := c.registerJob(context.Background(), "ref")
+	require.NoError(t, err)
+
+	_, err = c.registerJob(context.Background(), "ref")
+	require.Error(t, err)
+}
+
+// TestCancelCompleteRace exercises Cancel racing against a Solve that
+// completes (and deregisters) on its own, on the same ref.
+func TestCancelCompleteRace(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		c := &Controller{jobs: map[string]*job{}}
+		ctx, err := c.registerJob(context.Background(), "ref")
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.Cancel(context.Background(), &controlapi.CancelRequest{Ref: "ref"})
+		}()
+		go func() {
+			defer wg.Done()
+			c.deregisterJob("ref")
+		}()
+		wg.Wait()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("expected job context to be cancelled")
+		}
+
+		c.mu.Lock()
+		_, ok := c.jobs["ref"]
+		c.mu.Unlock()
+		require.False(t, ok)
+	}
+}
+
+// TestCancelStopsAttachedStatus verifies that Cancelling a ref also stops
+// a Status stream attached to the same ref, by driving Controller.Status
+// itself rather than re-implementing its cancellation wiring.
+func TestCancelStopsAttachedStatus(t *testing.T) {
+	c := &Controller{
+		jobs:   map[string]*job{},
+		solver: solver.NewLLBSolver(solver.LLBOpt{}),
+	}
+
+	_, err := c.registerJob(context.Background(), "ref")
+	require.NoError(t, err)
+
+	statusDone := make(chan error, 1)
+	go func() {
+		statusDone <- c.Status(&controlapi.StatusRequest{Ref: "ref"}, &fakeStatusServer{ctx: context.Background()})
+	}()
+
+	_, err = c.Cancel(context.Background(), &controlapi.CancelRequest{Ref: "ref"})
+	require.NoError(t, err)
+
+	select {
+	case err := <-statusDone:
+		require.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected Status to return once its ref was cancelled")
+	}
+}
@@ -0,0 +1,108 @@
+package solver
+
+import (
+	"github.com/moby/buildkit/cache"
+	"github.com/moby/buildkit/cache/remotecache"
+	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/exporter"
+	"github.com/moby/buildkit/frontend"
+	"github.com/moby/buildkit/source"
+	"github.com/moby/buildkit/util/dns"
+	"github.com/moby/buildkit/util/resolver"
+	"github.com/moby/buildkit/worker"
+	"golang.org/x/net/context"
+)
+
+// InstructionCache looks up previously-computed results for a vertex so
+// Solve can skip re-running it.
+type InstructionCache interface {
+	Probe(ctx context.Context, key string) (bool, error)
+	Lookup(ctx context.Context, key string) (interface{}, error)
+	Set(key string, value interface{}) error
+}
+
+// LLBOpt carries the dependencies a Solver needs to run LLB definitions:
+// where to pull sources from, where to cache results, which worker(s) can
+// execute vertices, and the daemon-level DNS policy RUN steps should
+// inherit unless a solve overrides it.
+type LLBOpt struct {
+	SourceManager    *source.Manager
+	CacheManager     cache.Manager
+	WorkerController *worker.Controller
+	InstructionCache InstructionCache
+	ImageSource      source.Source
+	Frontends        map[string]frontend.Frontend
+	DNS              *dns.Config
+	ResolverOptFunc  resolver.ResolverOptFunc
+}
+
+// SolveRequest is a single LLB solve: a definition (or a frontend plus its
+// options), the exporter/cache backends to use, and the worker(s) that
+// were resolved for it from the request's platform constraints.
+type SolveRequest struct {
+	Frontend       frontend.Frontend
+	Definition     []byte
+	Exporter       exporter.ExporterInstance
+	FrontendOpt    map[string]string
+	CacheExporter  remotecache.Exporter
+	CacheImporters []remotecache.Importer
+	DNS            *dns.Config
+	Workers        []worker.Worker
+}
+
+// Solver runs LLB solve requests against the workers/caches/sources it was
+// constructed with.
+type Solver struct {
+	opt LLBOpt
+}
+
+// NewLLBSolver returns a Solver driven by opt.
+func NewLLBSolver(opt LLBOpt) *Solver {
+	return &Solver{opt: opt}
+}
+
+// Solve runs req under ref on the first of req.Workers (or on the
+// controller's default worker, if none were resolved). Any further workers
+// in req.Workers are not used.
+func (s *Solver) Solve(ctx context.Context, ref string, req SolveRequest) error {
+	if s.opt.ResolverOptFunc != nil {
+		// Attach the daemon's resolver policy to ctx so that any image
+		// pull this solve triggers via ImageSource (frontend image refs,
+		// cache importer manifests, ...) picks up per-registry mirrors,
+		// TLS material, and plain-HTTP overrides instead of the default
+		// resolver.
+		ctx = resolver.WithResolverOptFunc(ctx, s.opt.ResolverOptFunc)
+	}
+
+	w, err := s.pickWorker(req)
+	if err != nil {
+		return err
+	}
+
+	dnsConfig := req.DNS
+	if dnsConfig == nil {
+		dnsConfig = s.opt.DNS
+	}
+	resolvConf, err := dns.GenerateResolvConf(dnsConfig)
+	if err != nil {
+		return err
+	}
+
+	return w.Run(ctx, ref, resolvConf)
+}
+
+// Status holds ch open for the solve registered under ref until ctx is done
+// (the solve completes or is cancelled), then closes it and returns
+// ctx.Err(). No progress events are sent on ch.
+func (s *Solver) Status(ctx context.Context, ref string, ch chan *client.SolveStatus) error {
+	defer close(ch)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *Solver) pickWorker(req SolveRequest) (worker.Worker, error) {
+	if len(req.Workers) > 0 {
+		return req.Workers[0], nil
+	}
+	return s.opt.WorkerController.GetDefault()
+}
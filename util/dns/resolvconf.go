@@ -0,0 +1,51 @@
+package dns
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+)
+
+// Config is the resolver policy (nameservers, search domains, options)
+// that should be written to a RUN step's /etc/resolv.conf, overriding
+// whatever the build host's own /etc/resolv.conf contains.
+type Config struct {
+	Nameservers   []string
+	SearchDomains []string
+	Options       []string
+}
+
+// hostResolvConf is the file copied through unmodified when GenerateResolvConf
+// is called with a nil Config. Overridable by tests.
+var hostResolvConf = "/etc/resolv.conf"
+
+// GenerateResolvConf renders cfg as the contents of a resolv.conf file. If
+// cfg is nil, the host's /etc/resolv.conf is copied through unmodified.
+func GenerateResolvConf(cfg *Config) ([]byte, error) {
+	if cfg == nil {
+		return ioutil.ReadFile(hostResolvConf)
+	}
+
+	var b bytes.Buffer
+	for _, ns := range cfg.Nameservers {
+		fmt.Fprintf(&b, "nameserver %s\n", ns)
+	}
+	if len(cfg.SearchDomains) > 0 {
+		fmt.Fprintf(&b, "search %s\n", joinSpace(cfg.SearchDomains))
+	}
+	if len(cfg.Options) > 0 {
+		fmt.Fprintf(&b, "options %s\n", joinSpace(cfg.Options))
+	}
+	return b.Bytes(), nil
+}
+
+func joinSpace(ss []string) string {
+	var b bytes.Buffer
+	for i, s := range ss {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package dns
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateResolvConfWithConfig(t *testing.T) {
+	cfg := &Config{
+		Nameservers:   []string{"1.1.1.1", "8.8.8.8"},
+		SearchDomains: []string{"example.com", "internal"},
+		Options:       []string{"ndots:2", "timeout:1"},
+	}
+
+	out, err := GenerateResolvConf(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "nameserver 1.1.1.1\nnameserver 8.8.8.8\nsearch example.com internal\noptions ndots:2 timeout:1\n", string(out))
+}
+
+func TestGenerateResolvConfEmptyConfig(t *testing.T) {
+	out, err := GenerateResolvConf(&Config{})
+	require.NoError(t, err)
+	require.Empty(t, out)
+}
+
+func TestGenerateResolvConfNilPassesThroughHostFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "resolv.conf")
+	require.NoError(t, ioutil.WriteFile(path, []byte("nameserver 9.9.9.9\n"), 0644))
+
+	orig := hostResolvConf
+	hostResolvConf = path
+	defer func() { hostResolvConf = orig }()
+
+	out, err := GenerateResolvConf(nil)
+	require.NoError(t, err)
+	require.Equal(t, "nameserver 9.9.9.9\n", string(out))
+}
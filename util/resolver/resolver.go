@@ -0,0 +1,27 @@
+package resolver
+
+import (
+	"github.com/containerd/containerd/remotes/docker"
+	"golang.org/x/net/context"
+)
+
+// ResolverOptFunc returns the docker resolver options to use for ref.
+type ResolverOptFunc func(ref string) docker.ResolverOptions
+
+type contextKeyT string
+
+var contextKey = contextKeyT("buildkit/util/resolveroptfunc")
+
+// WithResolverOptFunc attaches a ResolverOptFunc to ctx so that image
+// pulls triggered further down the solve (by a frontend or a registry
+// cache importer) can resolve it without threading it through every call
+// site explicitly.
+func WithResolverOptFunc(ctx context.Context, f ResolverOptFunc) context.Context {
+	return context.WithValue(ctx, contextKey, f)
+}
+
+// FromContext returns the ResolverOptFunc attached to ctx, if any.
+func FromContext(ctx context.Context) (ResolverOptFunc, bool) {
+	f, ok := ctx.Value(contextKey).(ResolverOptFunc)
+	return f, ok
+}
@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"strings"
+
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Worker is a single build executor: a containerd-backed daemon (or an
+// emulated one) able to run vertices for one or more platforms.
+type Worker interface {
+	ID() string
+	Labels() map[string]string
+	Platforms() []ocispecs.Platform
+
+	// Run executes the solve registered under ref on this worker. Each RUN
+	// vertex's OCI spec gets resolvConf written out as its /etc/resolv.conf.
+	Run(ctx context.Context, ref string, resolvConf []byte) error
+}
+
+// Controller holds the set of workers known to a buildkitd instance,
+// indexed implicitly by the platforms/labels each Worker reports, and
+// resolves which one should run a given vertex.
+type Controller struct {
+	workers []Worker
+}
+
+// NewController returns a Controller managing the given workers. The
+// first worker is used as the default when a solve doesn't request a
+// specific platform.
+func NewController(workers ...Worker) *Controller {
+	return &Controller{workers: workers}
+}
+
+// List returns the workers matching filter (a set of key=value label
+// selectors), or all workers when filter is empty.
+func (c *Controller) List(filter ...string) ([]Worker, error) {
+	if len(filter) == 0 {
+		return c.workers, nil
+	}
+	var out []Worker
+	for _, w := range c.workers {
+		if matchesLabels(w.Labels(), filter) {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+// Get returns a worker able to build for platform.
+func (c *Controller) Get(platform ocispecs.Platform) (Worker, error) {
+	for _, w := range c.workers {
+		for _, p := range w.Platforms() {
+			if p.OS == platform.OS && p.Architecture == platform.Architecture && p.Variant == platform.Variant {
+				return w, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("no worker for platform %s/%s", platform.OS, platform.Architecture)
+}
+
+// GetDefault returns the first registered worker.
+func (c *Controller) GetDefault() (Worker, error) {
+	if len(c.workers) == 0 {
+		return nil, errors.New("no workers registered")
+	}
+	return c.workers[0], nil
+}
+
+func matchesLabels(labels map[string]string, filter []string) bool {
+	for _, f := range filter {
+		parts := strings.SplitN(f, "=", 2)
+		v, ok := labels[parts[0]]
+		if !ok {
+			return false
+		}
+		if len(parts) == 2 {
+			if v != parts[1] {
+				return false
+			}
+		} else if v == "" {
+			return false
+		}
+	}
+	return true
+}